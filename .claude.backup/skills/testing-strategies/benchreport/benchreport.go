@@ -0,0 +1,329 @@
+// Package benchreport captures testing.B results in the format documented
+// at golang.org/design/14313-benchmark-format (the format `benchstat`
+// consumes) and compares two such files with a Welch's t-test, so an A/B
+// benchmark run can be checked for a statistically significant regression
+// without shelling out to a separate tool.
+package benchreport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Result is one captured benchmark observation, mirroring the fields
+// testing.B exposes plus anything reported via b.ReportMetric.
+type Result struct {
+	Name          string
+	Iterations    int64
+	NsPerOp       float64
+	AllocsPerOp   int64
+	BytesPerOp    int64
+	CustomMetrics map[string]string // b.ReportMetric unit -> formatted value; written as "<value> <unit>"
+}
+
+// Header carries the golang.org/design/14313 file header: a block of
+// `key: value` lines identifying the environment a report was captured in.
+type Header struct {
+	Goos   string
+	Goarch string
+	Pkg    string
+	Cpu    string
+	Commit string
+}
+
+// CurrentHeader fills in goos/goarch from the running process; pkg, commit
+// and cpu are caller-supplied since they aren't knowable from within the
+// package itself.
+func CurrentHeader(pkg, commit, cpu string) Header {
+	return Header{
+		Goos:   runtime.GOOS,
+		Goarch: runtime.GOARCH,
+		Pkg:    pkg,
+		Cpu:    cpu,
+		Commit: commit,
+	}
+}
+
+// WriteFile writes header followed by one row per result, in the
+// `BenchmarkName-GOMAXPROCS <iters> <value> <unit> ...` layout the
+// golang.org/design/14313 format and benchstat both expect.
+func WriteFile(path string, header Header, results []Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("benchreport: create %s: %w", path, err)
+	}
+	defer f.Close()
+	return Write(f, header, results)
+}
+
+// Write is WriteFile without the file-handling, for tests and for callers
+// that already have an io.Writer (e.g. TestMain writing to os.Stdout).
+func Write(w io.Writer, header Header, results []Result) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "goos: %s\n", header.Goos)
+	fmt.Fprintf(bw, "goarch: %s\n", header.Goarch)
+	fmt.Fprintf(bw, "pkg: %s\n", header.Pkg)
+	if header.Cpu != "" {
+		fmt.Fprintf(bw, "cpu: %s\n", header.Cpu)
+	}
+	if header.Commit != "" {
+		fmt.Fprintf(bw, "commit: %s\n", header.Commit)
+	}
+	fmt.Fprintln(bw)
+
+	for _, r := range results {
+		fmt.Fprintf(bw, "%s-%d\t%d\t%.2f ns/op", r.Name, runtime.GOMAXPROCS(0), r.Iterations, r.NsPerOp)
+		if r.BytesPerOp > 0 {
+			fmt.Fprintf(bw, "\t%d B/op", r.BytesPerOp)
+		}
+		if r.AllocsPerOp > 0 {
+			fmt.Fprintf(bw, "\t%d allocs/op", r.AllocsPerOp)
+		}
+		for key, val := range r.CustomMetrics {
+			fmt.Fprintf(bw, "\t%s %s", val, key)
+		}
+		fmt.Fprintln(bw)
+	}
+
+	return bw.Flush()
+}
+
+// Delta is the outcome of comparing one benchmark's ns/op samples between
+// an old and a new report.
+type Delta struct {
+	Name         string
+	OldMean      float64
+	NewMean      float64
+	PercentDelta float64
+	PValue       float64
+	Significant  bool // PValue < 0.05
+}
+
+// Compare reads oldFile and newFile and runs a Welch's t-test per
+// benchmark name present in both, the same statistic `benchstat` uses to
+// flag a regression versus run-to-run noise.
+func Compare(oldFile, newFile string) ([]Delta, error) {
+	oldSamples, err := readSamples(oldFile)
+	if err != nil {
+		return nil, fmt.Errorf("benchreport: %s: %w", oldFile, err)
+	}
+	newSamples, err := readSamples(newFile)
+	if err != nil {
+		return nil, fmt.Errorf("benchreport: %s: %w", newFile, err)
+	}
+
+	var deltas []Delta
+	for name, oldVals := range oldSamples {
+		newVals, ok := newSamples[name]
+		if !ok {
+			continue
+		}
+		d := welchTTest(name, oldVals, newVals)
+		deltas = append(deltas, d)
+	}
+	return deltas, nil
+}
+
+// readSamples parses a benchreport file back into per-benchmark-name
+// ns/op samples, tolerating multiple rows per name (repeated runs).
+func readSamples(path string) (map[string][]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	samples := map[string][]float64{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.Contains(line, ":") || !strings.HasPrefix(line, "Benchmark") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		name := stripGOMAXPROCS(fields[0])
+		nsPerOp, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			continue
+		}
+		samples[name] = append(samples[name], nsPerOp)
+	}
+	return samples, scanner.Err()
+}
+
+func stripGOMAXPROCS(field string) string {
+	idx := strings.LastIndex(field, "-")
+	if idx < 0 {
+		return field
+	}
+	if _, err := strconv.Atoi(field[idx+1:]); err != nil {
+		return field
+	}
+	return field[:idx]
+}
+
+// welchTTest implements Welch's t-test: unequal-variance means comparison
+// via the Welch-Satterthwaite degrees-of-freedom approximation, which is
+// what benchstat uses to decide whether a delta is noise.
+func welchTTest(name string, a, b []float64) Delta {
+	meanA, varA := meanVar(a)
+	meanB, varB := meanVar(b)
+
+	d := Delta{
+		Name:         name,
+		OldMean:      meanA,
+		NewMean:      meanB,
+		PercentDelta: percentDelta(meanA, meanB),
+	}
+
+	nA, nB := float64(len(a)), float64(len(b))
+	if nA < 2 || nB < 2 {
+		// Too few samples per side to estimate variance at all; stay
+		// inconclusive rather than claim significance either way.
+		d.PValue = 1
+		return d
+	}
+	if varA == 0 && varB == 0 {
+		// No within-group noise: equal means are a true non-difference,
+		// but any other difference is as significant as it gets (t would
+		// be infinite), not noise we can't distinguish from an effect.
+		if meanA == meanB {
+			d.PValue = 1
+		} else {
+			d.PValue = 0
+			d.Significant = true
+		}
+		return d
+	}
+
+	se := math.Sqrt(varA/nA + varB/nB)
+	t := (meanA - meanB) / se
+
+	dof := math.Pow(varA/nA+varB/nB, 2) /
+		(math.Pow(varA/nA, 2)/(nA-1) + math.Pow(varB/nB, 2)/(nB-1))
+
+	d.PValue = twoSidedPValue(t, dof)
+	d.Significant = d.PValue < 0.05
+	return d
+}
+
+func meanVar(vals []float64) (mean, variance float64) {
+	n := float64(len(vals))
+	if n == 0 {
+		return 0, 0
+	}
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= n
+	if n < 2 {
+		return mean, 0
+	}
+	for _, v := range vals {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= n - 1
+	return mean, variance
+}
+
+func percentDelta(old, new float64) float64 {
+	if old == 0 {
+		return 0
+	}
+	return (new - old) / old * 100
+}
+
+// twoSidedPValue approximates the two-sided p-value for Student's t
+// distribution via the regularized incomplete beta function, which is
+// accurate enough to threshold against 0.05 without pulling in a stats
+// dependency.
+func twoSidedPValue(t, dof float64) float64 {
+	x := dof / (dof + t*t)
+	return incompleteBeta(x, dof/2, 0.5)
+}
+
+// incompleteBeta computes the regularized incomplete beta function I_x(a,b)
+// via its continued-fraction expansion (Numerical Recipes §6.4).
+func incompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lbeta := lgamma(a+b) - lgamma(a) - lgamma(b) +
+		a*math.Log(x) + b*math.Log(1-x)
+	front := math.Exp(lbeta)
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+func lgamma(x float64) float64 {
+	v, _ := math.Lgamma(x)
+	return v
+}
+
+func betaContinuedFraction(x, a, b float64) float64 {
+	const maxIter = 200
+	const eps = 3e-14
+
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < 1e-30 {
+		d = 1e-30
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxIter; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < 1e-30 {
+			d = 1e-30
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < 1e-30 {
+			c = 1e-30
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < 1e-30 {
+			d = 1e-30
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < 1e-30 {
+			c = 1e-30
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h
+}
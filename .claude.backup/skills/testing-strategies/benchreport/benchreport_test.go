@@ -0,0 +1,98 @@
+package benchreport
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileThenReadSamplesRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "new.txt")
+
+	header := CurrentHeader("example", "abc123", "")
+	results := []Result{{Name: "BenchmarkFoo", Iterations: 1000, NsPerOp: 123.4, AllocsPerOp: 2, BytesPerOp: 16}}
+
+	if err := WriteFile(path, header, results); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	samples, err := readSamples(path)
+	if err != nil {
+		t.Fatalf("readSamples: %v", err)
+	}
+	if got := samples["BenchmarkFoo"]; len(got) != 1 || got[0] != 123.4 {
+		t.Errorf("samples[BenchmarkFoo] = %v, want [123.4]", got)
+	}
+}
+
+func TestCompareFlagsARegression(t *testing.T) {
+	dir := t.TempDir()
+	oldFile := filepath.Join(dir, "old.txt")
+	newFile := filepath.Join(dir, "new.txt")
+
+	header := CurrentHeader("example", "", "")
+	oldResults := repeatResult("BenchmarkFoo", 100, 5)
+	newResults := repeatResult("BenchmarkFoo", 400, 5) // 4x slower, consistently
+
+	if err := WriteFile(oldFile, header, oldResults); err != nil {
+		t.Fatalf("WriteFile(old): %v", err)
+	}
+	if err := WriteFile(newFile, header, newResults); err != nil {
+		t.Fatalf("WriteFile(new): %v", err)
+	}
+
+	deltas, err := Compare(oldFile, newFile)
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+	if len(deltas) != 1 {
+		t.Fatalf("want 1 delta, got %d", len(deltas))
+	}
+	if !deltas[0].Significant {
+		t.Errorf("expected a 4x slowdown to be flagged significant, p=%v", deltas[0].PValue)
+	}
+	if deltas[0].PercentDelta <= 0 {
+		t.Errorf("PercentDelta = %v, want > 0 for a slowdown", deltas[0].PercentDelta)
+	}
+}
+
+func TestCompareIgnoresNoise(t *testing.T) {
+	dir := t.TempDir()
+	oldFile := filepath.Join(dir, "old.txt")
+	newFile := filepath.Join(dir, "new.txt")
+
+	header := CurrentHeader("example", "", "")
+	oldResults := []Result{
+		{Name: "BenchmarkFoo", Iterations: 1000, NsPerOp: 100},
+		{Name: "BenchmarkFoo", Iterations: 1000, NsPerOp: 102},
+		{Name: "BenchmarkFoo", Iterations: 1000, NsPerOp: 98},
+	}
+	newResults := []Result{
+		{Name: "BenchmarkFoo", Iterations: 1000, NsPerOp: 101},
+		{Name: "BenchmarkFoo", Iterations: 1000, NsPerOp: 99},
+		{Name: "BenchmarkFoo", Iterations: 1000, NsPerOp: 103},
+	}
+
+	if err := WriteFile(oldFile, header, oldResults); err != nil {
+		t.Fatalf("WriteFile(old): %v", err)
+	}
+	if err := WriteFile(newFile, header, newResults); err != nil {
+		t.Fatalf("WriteFile(new): %v", err)
+	}
+
+	deltas, err := Compare(oldFile, newFile)
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+	if deltas[0].Significant {
+		t.Errorf("expected noise-level delta to not be significant, p=%v", deltas[0].PValue)
+	}
+}
+
+func repeatResult(name string, nsPerOp float64, n int) []Result {
+	results := make([]Result, n)
+	for i := range results {
+		results[i] = Result{Name: name, Iterations: 1000, NsPerOp: nsPerOp}
+	}
+	return results
+}
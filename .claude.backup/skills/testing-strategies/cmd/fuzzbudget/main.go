@@ -0,0 +1,91 @@
+// Command fuzzbudget runs every FuzzXxx function in a package for a bounded
+// amount of time each, for use as a CI gate: it exits non-zero when a
+// target produces a new entry under testdata/fuzz/<name>/ or when its
+// `go test` run fails without producing one (e.g. a build error), not
+// merely when the budget elapses without incident.
+//
+// Usage:
+//
+//	fuzzbudget --fuzz-budget=30s <package-dir>
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"skills-copilot-codespaces-vscode/testing-strategies/fuzzutil"
+)
+
+func main() {
+	budget := flag.Duration("fuzz-budget", 30*time.Second, "time budget per FuzzXxx target")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: fuzzbudget [--fuzz-budget=30s] <package-dir>")
+		os.Exit(2)
+	}
+	pkgDir := flag.Arg(0)
+
+	names, err := findFuzzFuncs(pkgDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fuzzbudget:", err)
+		os.Exit(1)
+	}
+	if len(names) == 0 {
+		fmt.Println("fuzzbudget: no FuzzXxx functions found, nothing to do")
+		return
+	}
+
+	var failed []string
+	for _, name := range names {
+		fmt.Printf("fuzzbudget: running %s for %s\n", name, *budget)
+		found, err := fuzzutil.RunBudgeted(pkgDir, name, *budget)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fuzzbudget: %s: %v\n", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		if found {
+			fmt.Printf("fuzzbudget: %s produced a new crash corpus entry\n", name)
+			failed = append(failed, name)
+		}
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintf(os.Stderr, "fuzzbudget: failing targets: %s\n", strings.Join(failed, ", "))
+		os.Exit(1)
+	}
+}
+
+func findFuzzFuncs(pkgDir string) ([]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, pkgDir, func(fi fs.FileInfo) bool {
+		return strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", pkgDir, err)
+	}
+
+	var names []string
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv != nil {
+					continue
+				}
+				if strings.HasPrefix(fn.Name.Name, "Fuzz") {
+					names = append(names, fn.Name.Name)
+				}
+			}
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,57 @@
+// Command loadtest runs a JSON load/soak-test scenario against the
+// built-in harness test types (http-get, sql-query, func-call) and exits
+// non-zero if the scenario's error-rate or P99 thresholds are exceeded.
+//
+// Usage:
+//
+//	loadtest --config path/to/scenario.json
+//
+// See harness/testdata/scenario.json for an example scenario file. To use
+// the "sql-query" test type, blank-import the database/sql driver your
+// scenario's "driver" param names (e.g. `_ "github.com/lib/pq"`) in a
+// fork of this file before building.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"skills-copilot-codespaces-vscode/testing-strategies/harness"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to a JSON scenario file")
+	flag.Parse()
+
+	if *configPath == "" {
+		fmt.Fprintln(os.Stderr, "usage: loadtest --config path.json")
+		os.Exit(2)
+	}
+
+	cfg, err := harness.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "loadtest:", err)
+		os.Exit(1)
+	}
+
+	runner := harness.NewRunner(cfg, os.Stdout)
+	result, err := runner.Run(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "loadtest:", err)
+		os.Exit(1)
+	}
+
+	for typ, snap := range result.Snapshots {
+		fmt.Fprintf(os.Stderr, "%s: count=%d errors=%d p50=%s p95=%s p99=%s\n",
+			typ, snap.Count, snap.Errors, snap.P50, snap.P95, snap.P99)
+	}
+
+	if len(result.ThresholdsFailed) > 0 {
+		for _, msg := range result.ThresholdsFailed {
+			fmt.Fprintln(os.Stderr, "loadtest: threshold exceeded:", msg)
+		}
+		os.Exit(1)
+	}
+}
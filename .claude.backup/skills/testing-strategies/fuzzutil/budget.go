@@ -0,0 +1,67 @@
+package fuzzutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// RunBudgeted runs `go test -run=^$ -fuzz=^name$ -fuzztime=budget` for a
+// single fuzz target in pkgDir and reports whether it produced a new
+// corpus entry under testdata/fuzz/<name>/ (i.e. found a failing input).
+// It is the building block behind `cmd/fuzzbudget`, which loops this over
+// every FuzzXxx function in a package so CI can bound total fuzz time
+// without treating "found nothing new" as a failure.
+func RunBudgeted(pkgDir, name string, budget time.Duration) (foundNewCrash bool, err error) {
+	seedDir := filepath.Join(pkgDir, "testdata", "fuzz", name)
+	before, err := corpusFileSet(seedDir)
+	if err != nil {
+		return false, err
+	}
+
+	cmd := exec.Command("go", "test",
+		"-run=^$",
+		"-fuzz=^"+name+"$",
+		"-fuzztime="+budget.String(),
+		pkgDir,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+
+	after, err := corpusFileSet(seedDir)
+	if err != nil {
+		return false, err
+	}
+
+	for f := range after {
+		if !before[f] {
+			return true, nil
+		}
+	}
+
+	// A nonzero exit with no new corpus entry means the test binary itself
+	// failed to build/run, which is a real error distinct from "fuzzing
+	// found a crash".
+	if runErr != nil {
+		return false, fmt.Errorf("fuzzutil: go test -fuzz=%s: %w", name, runErr)
+	}
+	return false, nil
+}
+
+func corpusFileSet(dir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		set[e.Name()] = true
+	}
+	return set, nil
+}
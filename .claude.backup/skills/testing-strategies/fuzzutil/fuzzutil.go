@@ -0,0 +1,194 @@
+// Package fuzzutil provides small helpers around the Go 1.18+ fuzzing
+// workflow: loading/saving the `testdata/fuzz/<FuzzName>/` seed corpus that
+// `go test -fuzz` reads natively, and expressing common fuzz properties
+// (round-trip, never-panics) without repeating the boilerplate in every
+// FuzzXxx function.
+package fuzzutil
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const corpusHeader = "go test fuzz v1"
+
+// LoadCorpus adds every seed file found in dir (conventionally
+// "testdata/fuzz/<FuzzName>") to f via f.Add, decoding the
+// `go test fuzz v1` line format that `go test -fuzz` itself writes for
+// discovered failures, and returns how many seeds were added (for
+// reporting via testreport.SetFuzzSeedCount). Missing dir is not an
+// error: a package may not have accumulated any saved corpus yet.
+func LoadCorpus(f *testing.F, dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("fuzzutil: read corpus dir %s: %w", dir, err)
+	}
+
+	count := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		vals, err := decodeCorpusFile(path)
+		if err != nil {
+			return count, fmt.Errorf("fuzzutil: %s: %w", path, err)
+		}
+		f.Add(vals...)
+		count++
+	}
+	return count, nil
+}
+
+// SaveMinimized writes input to dir as a new seed in the same
+// `go test fuzz v1` format, so a minimized crash input found during fuzzing
+// can be checked in as a regression seed. The file name is derived from the
+// subtest name t.Name() reports when run under `go test -run`.
+func SaveMinimized(t *testing.T, dir string, input ...interface{}) error {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("fuzzutil: mkdir %s: %w", dir, err)
+	}
+
+	name := sanitizeFilename(t.Name())
+	path := filepath.Join(dir, name)
+
+	var b strings.Builder
+	fmt.Fprintln(&b, corpusHeader)
+	for _, v := range input {
+		fmt.Fprintln(&b, encodeValue(v))
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("fuzzutil: write %s: %w", path, err)
+	}
+	return nil
+}
+
+// RoundTrip asserts that decode(encode(input)) reproduces input, the most
+// common property checked by a fuzz target for a serialization format.
+func RoundTrip[T comparable](t *testing.T, encode func(T) []byte, decode func([]byte) (T, error), input T) {
+	t.Helper()
+
+	encoded := encode(input)
+	decoded, err := decode(encoded)
+	if err != nil {
+		t.Fatalf("RoundTrip: decode failed: %v", err)
+	}
+	if decoded != input {
+		t.Fatalf("RoundTrip: got %v, want %v", decoded, input)
+	}
+}
+
+// NeverPanics runs fn and turns any panic into a test failure with the
+// recovered value attached, instead of crashing the fuzzer's worker
+// process outright.
+func NeverPanics(t *testing.T, fn func()) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("NeverPanics: panic: %v", r)
+		}
+	}()
+	fn()
+}
+
+func decodeCorpusFile(path string) ([]interface{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("empty corpus file")
+	}
+	if strings.TrimSpace(scanner.Text()) != corpusHeader {
+		return nil, fmt.Errorf("missing %q header", corpusHeader)
+	}
+
+	var vals []interface{}
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		v, err := decodeValue(line)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, v)
+	}
+	return vals, scanner.Err()
+}
+
+// decodeValue parses one "type(value)" corpus line for the scalar types the
+// stdlib fuzzer supports most commonly, matching the format `go test fuzz`
+// itself writes (see testing/internal/testdeps and the corpus files under
+// GOROOT/src/*/testdata/fuzz) rather than an ad-hoc one. Only string, int,
+// int64 and bool are decoded; any other type, including []byte and rune,
+// returns an "unsupported corpus type" error.
+func decodeValue(line string) (interface{}, error) {
+	open := strings.Index(line, "(")
+	if open < 0 || !strings.HasSuffix(line, ")") {
+		return nil, fmt.Errorf("malformed corpus line %q", line)
+	}
+	typ, raw := line[:open], line[open+1:len(line)-1]
+
+	switch typ {
+	case "string":
+		unquoted, err := strconv.Unquote(raw)
+		if err != nil {
+			return nil, fmt.Errorf("decode string: %w", err)
+		}
+		return unquoted, nil
+	case "int":
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", typ, err)
+		}
+		return n, nil
+	case "int64":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", typ, err)
+		}
+		return n, nil
+	case "bool":
+		return raw == "true", nil
+	default:
+		return nil, fmt.Errorf("unsupported corpus type %q", typ)
+	}
+}
+
+func encodeValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return "string(" + strconv.Quote(val) + ")"
+	case bool:
+		return fmt.Sprintf("bool(%t)", val)
+	case int:
+		return fmt.Sprintf("int(%d)", val)
+	case int64:
+		return fmt.Sprintf("int64(%d)", val)
+	default:
+		return fmt.Sprintf("string(%q)", fmt.Sprint(val))
+	}
+}
+
+func sanitizeFilename(name string) string {
+	name = strings.ReplaceAll(name, "/", "_")
+	name = strings.ReplaceAll(name, " ", "_")
+	return name
+}
@@ -0,0 +1,95 @@
+package fuzzutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveMinimizedThenLoadCorpusRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	seedDir := filepath.Join(dir, "FuzzExample")
+
+	if err := os.MkdirAll(seedDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := SaveMinimized(t, seedDir, "hello"); err != nil {
+		t.Fatalf("SaveMinimized: %v", err)
+	}
+
+	// decodeCorpusFile is the per-file step LoadCorpus runs; LoadCorpus
+	// itself needs a *testing.F to add the decoded values to.
+	vals, err := decodeCorpusFile(filepath.Join(seedDir, sanitizeFilename(t.Name())))
+	if err != nil {
+		t.Fatalf("decodeCorpusFile: %v", err)
+	}
+	if len(vals) != 1 || vals[0].(string) != "hello" {
+		t.Fatalf("decoded %v, want [hello]", vals)
+	}
+}
+
+func TestDecodeValuePreservesIntVsInt64Type(t *testing.T) {
+	dir := t.TempDir()
+	seedDir := filepath.Join(dir, "FuzzExample")
+
+	if err := os.MkdirAll(seedDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(seedDir, "seed1"), []byte("go test fuzz v1\nint(5)\nint64(5)\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	vals, err := decodeCorpusFile(filepath.Join(seedDir, "seed1"))
+	if err != nil {
+		t.Fatalf("decodeCorpusFile: %v", err)
+	}
+	if len(vals) != 2 {
+		t.Fatalf("decoded %v, want 2 values", vals)
+	}
+	if _, ok := vals[0].(int); !ok {
+		t.Errorf("int(5) decoded as %T, want int", vals[0])
+	}
+	if _, ok := vals[1].(int64); !ok {
+		t.Errorf("int64(5) decoded as %T, want int64", vals[1])
+	}
+}
+
+func TestDecodeCorpusFileRejectsMissingHeader(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bad")
+	if err := os.WriteFile(path, []byte("not a corpus file\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := decodeCorpusFile(path); err == nil {
+		t.Fatal("expected error for missing corpus header")
+	}
+}
+
+func TestNeverPanicsCatchesPanic(t *testing.T) {
+	// t.Run can't be used here: a failing subtest unconditionally marks
+	// every ancestor *testing.T failed too, regardless of what the caller
+	// does with the returned bool, so a real subtest would fail this test
+	// no matter what we assert. Instead, run NeverPanics against a bare,
+	// disconnected *testing.T in its own goroutine (t.Fatalf ends in
+	// runtime.Goexit, which only unwinds that goroutine) and inspect it
+	// from here once it's done.
+	inner := &testing.T{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NeverPanics(inner, func() { panic("boom") })
+	}()
+	<-done
+	if !inner.Failed() {
+		t.Fatal("NeverPanics should mark t failed when fn panics")
+	}
+}
+
+func TestNeverPanicsAllowsCleanFn(t *testing.T) {
+	passed := t.Run("clean fn", func(t *testing.T) {
+		NeverPanics(t, func() {})
+	})
+	if !passed {
+		t.Fatal("NeverPanics should not fail when fn does not panic")
+	}
+}
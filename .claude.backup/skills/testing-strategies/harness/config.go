@@ -0,0 +1,157 @@
+// Package harness implements a load/soak-test runner, the perf-testing
+// counterpart to the unit-test scaffold in templates/unit: it drives a
+// configurable number of concurrent Test implementations, aggregates their
+// latency and error rates, and fails a run when configured thresholds are
+// exceeded. See cmd/loadtest for the CLI entry point.
+package harness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Strategy selects how the Runner schedules work across goroutines.
+type Strategy string
+
+const (
+	// StrategyConcurrent holds Concurrency goroutines running continuously
+	// for Duration, per TestConfig (TestConfig.Count overrides Concurrency
+	// when set).
+	StrategyConcurrent Strategy = "concurrent"
+	// StrategyLinearRamp grows from 1 to Concurrency goroutines evenly
+	// over Duration, for finding the load level where latency knees over.
+	// TestConfig.Count overrides Concurrency when set, same as
+	// StrategyConcurrent.
+	StrategyLinearRamp Strategy = "linear-ramp"
+)
+
+// TestConfig describes one registered Test type to run, and how many
+// logical "virtual users" of it to schedule.
+type TestConfig struct {
+	Type string `json:"type"`
+	// Name identifies this entry in Result.Snapshots and progress logs.
+	// Defaults to Type, but must be set explicitly when two entries share
+	// a Type (e.g. two "http-get" targets hitting different URLs) so they
+	// don't collide under the same key.
+	Name string `json:"name"`
+	// Count is the number of concurrent goroutines to run this test type
+	// with. If zero, Config.Concurrency is used instead.
+	Count  int                    `json:"count"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// key returns the Result.Snapshots / progress-log identifier for tc: Name
+// if set, otherwise Type.
+func (tc TestConfig) key() string {
+	if tc.Name != "" {
+		return tc.Name
+	}
+	return tc.Type
+}
+
+// Thresholds fails a run when exceeded; either field left at its zero
+// value is not checked.
+type Thresholds struct {
+	ErrorRate float64 `json:"error_rate"` // fraction, e.g. 0.01 for 1%
+	P99       string  `json:"p99"`        // parsed with time.ParseDuration
+}
+
+// Config is the JSON scenario file loadtest and Runner.Run consume.
+type Config struct {
+	Strategy    Strategy     `json:"strategy"`
+	Concurrency int          `json:"concurrency"`
+	Duration    string       `json:"duration"` // parsed with time.ParseDuration
+	Timeout     string       `json:"timeout"`  // per-iteration timeout
+	Tests       []TestConfig `json:"tests"`
+	Thresholds  Thresholds   `json:"thresholds"`
+}
+
+// LoadConfig reads and validates a scenario file from path.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("harness: read %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("harness: parse %s: %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return Config{}, fmt.Errorf("harness: %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (c Config) validate() error {
+	if c.Strategy != StrategyConcurrent && c.Strategy != StrategyLinearRamp {
+		return fmt.Errorf("unknown strategy %q", c.Strategy)
+	}
+	if c.Concurrency <= 0 {
+		return fmt.Errorf("concurrency must be > 0")
+	}
+	if _, err := c.duration(); err != nil {
+		return fmt.Errorf("duration: %w", err)
+	}
+	if len(c.Tests) == 0 {
+		return fmt.Errorf("no tests configured")
+	}
+	seen := make(map[string]bool, len(c.Tests))
+	for _, tc := range c.Tests {
+		k := tc.key()
+		if seen[k] {
+			return fmt.Errorf("duplicate test %q (set distinct \"name\" fields)", k)
+		}
+		seen[k] = true
+	}
+	return nil
+}
+
+func (c Config) duration() (time.Duration, error) {
+	return time.ParseDuration(c.Duration)
+}
+
+func (c Config) timeout() (time.Duration, error) {
+	if c.Timeout == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(c.Timeout)
+}
+
+func (c Config) p99Threshold() (time.Duration, error) {
+	if c.Thresholds.P99 == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(c.Thresholds.P99)
+}
+
+// Test is implemented by each load-test workload. Run performs one
+// iteration; the Runner calls it repeatedly according to the configured
+// Strategy until Duration elapses.
+type Test interface {
+	Run(ctx context.Context, log *Logger) error
+}
+
+// Factory builds a Test from its Params, as registered via Register.
+type Factory func(params map[string]interface{}) (Test, error)
+
+var registry = map[string]Factory{}
+
+// Register adds a Test factory under typeName, for use in a TestConfig's
+// "type" field. Built-in types (http-get, sql-query, func-call) register
+// themselves via init(); callers can register their own types the same
+// way before calling Runner.Run.
+func Register(typeName string, factory Factory) {
+	registry[typeName] = factory
+}
+
+func lookup(typeName string) (Factory, error) {
+	factory, ok := registry[typeName]
+	if !ok {
+		return nil, fmt.Errorf("harness: no test type registered as %q", typeName)
+	}
+	return factory, nil
+}
@@ -0,0 +1,192 @@
+package harness
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadConfigValidatesStrategy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scenario.json")
+	if err := os.WriteFile(path, []byte(`{"strategy":"bogus","concurrency":1,"duration":"1s","tests":[{"type":"func-call"}]}`), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("expected an error for an unknown strategy")
+	}
+}
+
+func TestRunnerAggregatesFuncCallResults(t *testing.T) {
+	calls := 0
+	RegisterFunc("test-ok", func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+	RegisterFunc("test-fail", func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+
+	cfg := Config{
+		Strategy:    StrategyConcurrent,
+		Concurrency: 2,
+		Duration:    "50ms",
+		Tests: []TestConfig{
+			{Type: "func-call", Count: 2, Params: map[string]interface{}{"name": "test-ok"}},
+		},
+		Thresholds: Thresholds{ErrorRate: 1.0}, // disable error gating for this case
+	}
+
+	var progress bytes.Buffer
+	runner := NewRunner(cfg, &progress)
+
+	result, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	snap := result.Snapshots["func-call"]
+	if snap.Count == 0 {
+		t.Fatal("expected at least one observed iteration")
+	}
+	if snap.Errors != 0 {
+		t.Errorf("Errors = %d, want 0", snap.Errors)
+	}
+	if len(result.ThresholdsFailed) != 0 {
+		t.Errorf("ThresholdsFailed = %v, want none", result.ThresholdsFailed)
+	}
+	if calls == 0 {
+		t.Error("expected the registered func to have been invoked")
+	}
+
+	// Progress should be valid JSON lines.
+	dec := json.NewDecoder(&progress)
+	var lineCount int
+	for dec.More() {
+		var line map[string]interface{}
+		if err := dec.Decode(&line); err != nil {
+			t.Fatalf("progress line %d: invalid JSON: %v", lineCount, err)
+		}
+		lineCount++
+	}
+	if lineCount == 0 {
+		t.Error("expected at least one JSON-lines progress entry")
+	}
+}
+
+func TestRunnerFlagsErrorRateThreshold(t *testing.T) {
+	RegisterFunc("test-fail", func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+
+	cfg := Config{
+		Strategy:    StrategyConcurrent,
+		Concurrency: 1,
+		Duration:    "30ms",
+		Tests: []TestConfig{
+			{Type: "func-call", Count: 1, Params: map[string]interface{}{"name": "test-fail"}},
+		},
+		Thresholds: Thresholds{ErrorRate: 0.01},
+	}
+
+	runner := NewRunner(cfg, &bytes.Buffer{})
+	result, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.ThresholdsFailed) == 0 {
+		t.Error("expected the error-rate threshold to be flagged")
+	}
+}
+
+func TestLoadConfigRejectsDuplicateTestNames(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scenario.json")
+	body := `{"strategy":"concurrent","concurrency":1,"duration":"1s","tests":[
+		{"type":"func-call","count":1},
+		{"type":"func-call","count":1}
+	]}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("expected an error for two tests sharing a type with no distinguishing name")
+	}
+}
+
+func TestRunnerKeysSnapshotsByNameNotJustType(t *testing.T) {
+	RegisterFunc("test-ok", func(ctx context.Context) error { return nil })
+
+	cfg := Config{
+		Strategy:    StrategyConcurrent,
+		Concurrency: 1,
+		Duration:    "30ms",
+		Tests: []TestConfig{
+			{Type: "func-call", Name: "first", Count: 1, Params: map[string]interface{}{"name": "test-ok"}},
+			{Type: "func-call", Name: "second", Count: 1, Params: map[string]interface{}{"name": "test-ok"}},
+		},
+		Thresholds: Thresholds{ErrorRate: 1.0},
+	}
+
+	result, err := NewRunner(cfg, &bytes.Buffer{}).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if _, ok := result.Snapshots["first"]; !ok {
+		t.Error(`expected Snapshots["first"]`)
+	}
+	if _, ok := result.Snapshots["second"]; !ok {
+		t.Error(`expected Snapshots["second"]`)
+	}
+}
+
+func TestRunnerFallsBackToConfigConcurrencyWhenCountIsZero(t *testing.T) {
+	RegisterFunc("test-ok", func(ctx context.Context) error { return nil })
+
+	cfg := Config{
+		Strategy:    StrategyConcurrent,
+		Concurrency: 3,
+		Duration:    "30ms",
+		Tests: []TestConfig{
+			{Type: "func-call", Params: map[string]interface{}{"name": "test-ok"}},
+		},
+		Thresholds: Thresholds{ErrorRate: 1.0},
+	}
+
+	result, err := NewRunner(cfg, &bytes.Buffer{}).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Snapshots["func-call"].Count == 0 {
+		t.Fatal("expected at least one observed iteration using Config.Concurrency as the goroutine count")
+	}
+}
+
+func TestHistogramPercentiles(t *testing.T) {
+	h := newHistogram()
+	for i := 1; i <= 100; i++ {
+		h.observe(time.Duration(i)*time.Millisecond, nil)
+	}
+
+	snap := h.snapshot()
+	if snap.Count != 100 {
+		t.Fatalf("Count = %d, want 100", snap.Count)
+	}
+	// Bucketed percentiles are approximate; just check monotonicity and
+	// that P99 is near the top of the observed range.
+	if !(snap.P50 < snap.P95 && snap.P95 <= snap.P99) {
+		t.Errorf("expected P50 < P95 <= P99, got %v, %v, %v", snap.P50, snap.P95, snap.P99)
+	}
+	// Buckets are ~10% wide, so P99 lands between the 99th sample and one
+	// bucket above the 100ms maximum.
+	if snap.P99 < 99*time.Millisecond || snap.P99 > 110*time.Millisecond {
+		t.Errorf("P99 = %v, want within [99ms, 110ms]", snap.P99)
+	}
+}
@@ -0,0 +1,104 @@
+package harness
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// histogram accumulates latency samples for one test type using
+// exponentially-spaced buckets (an HDR-style fixed-width-in-log-space
+// scheme), so percentile queries don't require keeping every raw sample in
+// memory for a long soak run.
+type histogram struct {
+	mu      sync.Mutex
+	buckets map[int]int64 // bucket index -> count
+	count   int64
+	errors  int64
+	sum     time.Duration
+}
+
+// bucketBase controls resolution: each bucket covers roughly a 10% wider
+// range than the last, which keeps both microsecond and multi-second
+// latencies representable in a few hundred buckets.
+const bucketBase = 1.1
+
+func newHistogram() *histogram {
+	return &histogram{buckets: map[int]int64{}}
+}
+
+func (h *histogram) observe(d time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.count++
+	h.sum += d
+	if err != nil {
+		h.errors++
+	}
+	h.buckets[bucketIndex(d)]++
+}
+
+func bucketIndex(d time.Duration) int {
+	ns := float64(d.Nanoseconds())
+	if ns < 1 {
+		return 0
+	}
+	return int(math.Log(ns) / math.Log(bucketBase))
+}
+
+func bucketUpperBound(idx int) time.Duration {
+	return time.Duration(math.Pow(bucketBase, float64(idx+1)))
+}
+
+// percentile returns the latency below which p (0..1) of observed samples
+// fall, derived from the bucket counts rather than sorting raw samples.
+func (h *histogram) percentile(p float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.count == 0 {
+		return 0
+	}
+
+	indices := make([]int, 0, len(h.buckets))
+	for idx := range h.buckets {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+
+	target := int64(math.Ceil(p * float64(h.count)))
+	var cumulative int64
+	for _, idx := range indices {
+		cumulative += h.buckets[idx]
+		if cumulative >= target {
+			return bucketUpperBound(idx)
+		}
+	}
+	return bucketUpperBound(indices[len(indices)-1])
+}
+
+// Snapshot is a point-in-time summary of one test type's observations.
+type Snapshot struct {
+	Count     int64         `json:"count"`
+	Errors    int64         `json:"errors"`
+	ErrorRate float64       `json:"error_rate"`
+	Mean      time.Duration `json:"mean"`
+	P50       time.Duration `json:"p50"`
+	P95       time.Duration `json:"p95"`
+	P99       time.Duration `json:"p99"`
+}
+
+func (h *histogram) snapshot() Snapshot {
+	h.mu.Lock()
+	count, errors, sum := h.count, h.errors, h.sum
+	h.mu.Unlock()
+
+	s := Snapshot{Count: count, Errors: errors, P50: h.percentile(0.50), P95: h.percentile(0.95), P99: h.percentile(0.99)}
+	if count > 0 {
+		s.ErrorRate = float64(errors) / float64(count)
+		s.Mean = sum / time.Duration(count)
+	}
+	return s
+}
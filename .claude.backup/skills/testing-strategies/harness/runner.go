@@ -0,0 +1,143 @@
+package harness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Logger writes structured JSON-lines progress to an underlying writer; it
+// is passed to every Test.Run so workloads can emit their own progress
+// alongside the Runner's.
+type Logger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newLogger(w io.Writer) *Logger {
+	return &Logger{w: w}
+}
+
+// Log writes one JSON line of the form {"test": name, ...fields}.
+func (l *Logger) Log(testType string, fields map[string]interface{}) {
+	line := map[string]interface{}{"test": testType}
+	for k, v := range fields {
+		line[k] = v
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	enc := json.NewEncoder(l.w)
+	_ = enc.Encode(line)
+}
+
+// Result is the final outcome of a Runner.Run call: a Snapshot per
+// TestConfig, keyed by its Name (or Type when Name is unset), plus whether
+// any configured Threshold was exceeded.
+type Result struct {
+	Snapshots        map[string]Snapshot
+	ThresholdsFailed []string
+}
+
+// Runner drives a Config's test types according to its Strategy and
+// streams progress to progress (typically os.Stdout).
+type Runner struct {
+	Config   Config
+	progress io.Writer
+}
+
+// NewRunner builds a Runner for cfg, streaming JSON-lines progress to w.
+func NewRunner(cfg Config, w io.Writer) *Runner {
+	return &Runner{Config: cfg, progress: w}
+}
+
+// Run executes the configured scenario until its Duration elapses (or ctx
+// is cancelled) and returns the aggregated Result.
+func (r *Runner) Run(ctx context.Context) (Result, error) {
+	duration, err := r.Config.duration()
+	if err != nil {
+		return Result{}, err
+	}
+	timeout, err := r.Config.timeout()
+	if err != nil {
+		return Result{}, err
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	log := newLogger(r.progress)
+	histograms := make(map[string]*histogram, len(r.Config.Tests))
+
+	var wg sync.WaitGroup
+	for _, tc := range r.Config.Tests {
+		factory, err := lookup(tc.Type)
+		if err != nil {
+			return Result{}, err
+		}
+		test, err := factory(tc.Params)
+		if err != nil {
+			return Result{}, fmt.Errorf("harness: build %s: %w", tc.Type, err)
+		}
+
+		concurrency := tc.Count
+		if concurrency == 0 {
+			concurrency = r.Config.Concurrency
+		}
+
+		h := newHistogram()
+		histograms[tc.key()] = h
+
+		wg.Add(1)
+		go func(tc TestConfig, test Test, concurrency int, h *histogram) {
+			defer wg.Done()
+			schedule(runCtx, r.Config.Strategy, concurrency, duration, func(workerCtx context.Context) {
+				runOne(workerCtx, tc.key(), test, timeout, h, log)
+			})
+		}(tc, test, concurrency, h)
+	}
+	wg.Wait()
+
+	result := Result{Snapshots: make(map[string]Snapshot, len(histograms))}
+	for typ, h := range histograms {
+		snap := h.snapshot()
+		result.Snapshots[typ] = snap
+
+		if r.Config.Thresholds.ErrorRate > 0 && snap.ErrorRate > r.Config.Thresholds.ErrorRate {
+			result.ThresholdsFailed = append(result.ThresholdsFailed,
+				fmt.Sprintf("%s: error rate %.2f%% exceeds threshold %.2f%%", typ, snap.ErrorRate*100, r.Config.Thresholds.ErrorRate*100))
+		}
+		if p99, err := r.Config.p99Threshold(); err == nil && p99 > 0 && snap.P99 > p99 {
+			result.ThresholdsFailed = append(result.ThresholdsFailed,
+				fmt.Sprintf("%s: p99 %s exceeds threshold %s", typ, snap.P99, p99))
+		}
+	}
+
+	return result, nil
+}
+
+// runOne executes a single Test iteration, applying the per-iteration
+// timeout and recording latency/error into h. key identifies the owning
+// TestConfig (see TestConfig.key) for progress logging.
+func runOne(ctx context.Context, key string, test Test, timeout time.Duration, h *histogram, log *Logger) {
+	iterCtx := ctx
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		iterCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := test.Run(iterCtx, log)
+	elapsed := time.Since(start)
+
+	h.observe(elapsed, err)
+	fields := map[string]interface{}{"elapsed_ms": elapsed.Milliseconds()}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	log.Log(key, fields)
+}
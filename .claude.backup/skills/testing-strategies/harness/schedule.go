@@ -0,0 +1,80 @@
+package harness
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// schedule spawns goroutines that repeatedly call work until ctx is done,
+// according to strategy. concurrency is the target/peak number of
+// simultaneously running workers; duration is only used by
+// StrategyLinearRamp to compute the ramp-up rate.
+func schedule(ctx context.Context, strategy Strategy, concurrency int, duration time.Duration, work func(context.Context)) {
+	switch strategy {
+	case StrategyLinearRamp:
+		scheduleLinearRamp(ctx, concurrency, duration, work)
+	default:
+		scheduleConcurrent(ctx, concurrency, work)
+	}
+}
+
+// scheduleConcurrent holds `concurrency` goroutines continuously calling
+// work back-to-back until ctx is cancelled.
+func scheduleConcurrent(ctx context.Context, concurrency int, work func(context.Context)) {
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				work(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// scheduleLinearRamp starts one worker, then adds another at an even
+// cadence across duration until concurrency workers are running, so a run
+// can find the load level where latency starts to knee over.
+func scheduleLinearRamp(ctx context.Context, concurrency int, duration time.Duration, work func(context.Context)) {
+	if concurrency <= 1 {
+		scheduleConcurrent(ctx, concurrency, work)
+		return
+	}
+
+	interval := duration / time.Duration(concurrency)
+	if interval <= 0 {
+		scheduleConcurrent(ctx, concurrency, work)
+		return
+	}
+
+	var wg sync.WaitGroup
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	started := 0
+	spawn := func() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				work(ctx)
+			}
+		}()
+		started++
+	}
+
+	spawn() // first worker starts immediately
+	for started < concurrency {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+			spawn()
+		}
+	}
+	wg.Wait()
+}
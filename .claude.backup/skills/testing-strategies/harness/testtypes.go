@@ -0,0 +1,110 @@
+package harness
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+func init() {
+	Register("http-get", newHTTPGetTest)
+	Register("sql-query", newSQLQueryTest)
+	Register("func-call", newFuncCallTest)
+}
+
+// httpGetTest issues a GET request per iteration. Params: {"url": "..."}.
+type httpGetTest struct {
+	client *http.Client
+	url    string
+}
+
+func newHTTPGetTest(params map[string]interface{}) (Test, error) {
+	url, ok := params["url"].(string)
+	if !ok || url == "" {
+		return nil, fmt.Errorf("http-get: missing \"url\" param")
+	}
+	return &httpGetTest{client: http.DefaultClient, url: url}, nil
+}
+
+func (t *httpGetTest) Run(ctx context.Context, log *Logger) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("http-get: server error %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sqlQueryTest runs a query against a shared *sql.DB per iteration.
+// Params: {"driver": "...", "dsn": "...", "query": "..."}. The driver must
+// already be registered via the usual database/sql blank import in the
+// caller's main package; harness does not import driver packages itself.
+type sqlQueryTest struct {
+	db    *sql.DB
+	query string
+}
+
+func newSQLQueryTest(params map[string]interface{}) (Test, error) {
+	driver, _ := params["driver"].(string)
+	dsn, _ := params["dsn"].(string)
+	query, _ := params["query"].(string)
+	if driver == "" || dsn == "" || query == "" {
+		return nil, fmt.Errorf("sql-query: requires \"driver\", \"dsn\", and \"query\" params")
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sql-query: open: %w", err)
+	}
+	return &sqlQueryTest{db: db, query: query}, nil
+}
+
+func (t *sqlQueryTest) Run(ctx context.Context, log *Logger) error {
+	rows, err := t.db.QueryContext(ctx, t.query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+// funcCallTest invokes a Go function registered by name, for load-testing
+// in-process code paths rather than a network dependency. Since JSON
+// scenario files can't encode a func value, callers register the target
+// with RegisterFunc before loading the config. Params: {"name": "..."}.
+type funcCallTest struct {
+	fn func(ctx context.Context) error
+}
+
+var funcRegistry = map[string]func(ctx context.Context) error{}
+
+// RegisterFunc makes fn available to a "func-call" TestConfig under name.
+func RegisterFunc(name string, fn func(ctx context.Context) error) {
+	funcRegistry[name] = fn
+}
+
+func newFuncCallTest(params map[string]interface{}) (Test, error) {
+	name, _ := params["name"].(string)
+	fn, ok := funcRegistry[name]
+	if !ok {
+		return nil, fmt.Errorf("func-call: no func registered as %q (call harness.RegisterFunc first)", name)
+	}
+	return &funcCallTest{fn: fn}, nil
+}
+
+func (t *funcCallTest) Run(ctx context.Context, log *Logger) error {
+	return t.fn(ctx)
+}
@@ -0,0 +1,193 @@
+// Package httptestutil provides a controllable fake HTTP server for tests
+// that exercise an HTTP client, complementing the in-process interface
+// mocks in templates/unit/go-unit-test.template_test.go's MOCK TESTS
+// section. Unlike a bare httptest.NewServer, it records every request for
+// post-hoc assertions and lets a test script canned responses and latency
+// per path prefix, plus 5xx bursts across all paths.
+package httptestutil
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Response is a canned reply for a path prefix.
+type Response struct {
+	Status  int
+	Body    []byte
+	Headers map[string]string
+	Latency time.Duration // delay added before replying, for resilience tests
+}
+
+// Server is a fake HTTP server bound to an ephemeral port. Build one with
+// NewServer; it is torn down automatically via t.Cleanup.
+type Server struct {
+	t   *testing.T
+	srv *httptest.Server
+
+	mu        sync.Mutex
+	responses map[string]Response  // path prefix -> canned response
+	requests  []*http.Request      // captured, body already read
+	waiters   []chan *http.Request // WaitRequest subscribers, in arrival order
+	failBurst int                  // remaining requests to answer with 5xx
+}
+
+// NewServer starts a fake server and registers its shutdown with t.Cleanup.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+
+	s := &Server{t: t, responses: map[string]Response{}}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+// URL is the base URL ("http://127.0.0.1:PORT") requests should be sent to.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Respond registers the canned response for any request path with the
+// given prefix ("" matches everything). Later registrations for the same
+// prefix replace earlier ones.
+func (s *Server) Respond(pathPrefix string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[pathPrefix] = resp
+}
+
+// FailNext makes the next n requests (across all paths) receive a 500, to
+// simulate a resilience-test 5xx burst. Requests beyond n fall back to
+// whatever canned response (or 200) would otherwise apply.
+func (s *Server) FailNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failBurst = n
+}
+
+// Requests returns every request captured so far, in arrival order, for
+// post-hoc assertions (method, path, headers, and body are already read
+// into memory).
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*http.Request, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+// WaitRequest blocks until the next request arrives (in FIFO order
+// relative to other WaitRequest callers) or timeout elapses, returning nil
+// on timeout.
+func (s *Server) WaitRequest(timeout time.Duration) *http.Request {
+	ch := make(chan *http.Request, 1)
+
+	s.mu.Lock()
+	s.waiters = append(s.waiters, ch)
+	s.mu.Unlock()
+
+	select {
+	case req := <-ch:
+		return req
+	case <-time.After(timeout):
+		s.removeWaiter(ch)
+		return nil
+	}
+}
+
+// removeWaiter drops ch from s.waiters, for a WaitRequest call that timed
+// out. Without this, the abandoned channel stays first in line and the
+// next real request is delivered to it instead of to whichever caller is
+// actually still waiting.
+func (s *Server) removeWaiter(ch chan *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, w := range s.waiters {
+		if w == ch {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// Flush drains any WaitRequest callers still waiting with a nil request,
+// so a deferred teardown doesn't leave goroutines blocked past the end of
+// a test.
+func (s *Server) Flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.waiters {
+		select {
+		case ch <- nil:
+		default:
+		}
+	}
+	s.waiters = nil
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	captured := r.Clone(r.Context())
+	captured.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, captured)
+	for len(s.waiters) > 0 {
+		ch := s.waiters[0]
+		s.waiters = s.waiters[1:]
+		ch <- captured
+		break
+	}
+
+	failing := s.failBurst > 0
+	if failing {
+		s.failBurst--
+	}
+	resp, hasResp := s.lookupResponse(r.URL.Path)
+	s.mu.Unlock()
+
+	if failing {
+		http.Error(w, "httptestutil: simulated failure", http.StatusInternalServerError)
+		return
+	}
+
+	if resp.Latency > 0 {
+		time.Sleep(resp.Latency)
+	}
+	for k, v := range resp.Headers {
+		w.Header().Set(k, v)
+	}
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if !hasResp {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	w.Write(resp.Body)
+}
+
+// lookupResponse finds the longest registered prefix matching path. Caller
+// must hold s.mu.
+func (s *Server) lookupResponse(path string) (Response, bool) {
+	var best Response
+	bestLen := -1
+	found := false
+	for prefix, resp := range s.responses {
+		if len(prefix) > bestLen && hasPrefix(path, prefix) {
+			best, bestLen, found = resp, len(prefix), true
+		}
+	}
+	return best, found
+}
+
+func hasPrefix(path, prefix string) bool {
+	return len(path) >= len(prefix) && path[:len(prefix)] == prefix
+}
@@ -0,0 +1,111 @@
+package httptestutil
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRespondReturnsCannedResponseByPrefix(t *testing.T) {
+	srv := NewServer(t)
+	srv.Respond("/users", Response{Status: http.StatusCreated, Body: []byte(`{"id":1}`)})
+
+	resp, err := http.Get(srv.URL() + "/users/1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"id":1}` {
+		t.Errorf("body = %q, want %q", body, `{"id":1}`)
+	}
+}
+
+func TestRequestsRecordsCapturedRequests(t *testing.T) {
+	srv := NewServer(t)
+
+	if _, err := http.Post(srv.URL()+"/orders", "application/json", nil); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 1 {
+		t.Fatalf("len(Requests()) = %d, want 1", len(reqs))
+	}
+	if reqs[0].Method != http.MethodPost || reqs[0].URL.Path != "/orders" {
+		t.Errorf("captured request = %s %s, want POST /orders", reqs[0].Method, reqs[0].URL.Path)
+	}
+}
+
+func TestWaitRequestReturnsNextRequest(t *testing.T) {
+	srv := NewServer(t)
+
+	done := make(chan *http.Request, 1)
+	go func() { done <- srv.WaitRequest(time.Second) }()
+
+	if _, err := http.Get(srv.URL() + "/ping"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := <-done
+	if req == nil || req.URL.Path != "/ping" {
+		t.Fatalf("WaitRequest returned %v, want a /ping request", req)
+	}
+}
+
+func TestWaitRequestTimesOut(t *testing.T) {
+	srv := NewServer(t)
+	if req := srv.WaitRequest(10 * time.Millisecond); req != nil {
+		t.Fatalf("WaitRequest = %v, want nil on timeout", req)
+	}
+}
+
+func TestWaitRequestAfterTimeoutDoesNotStealTheNextWaiter(t *testing.T) {
+	srv := NewServer(t)
+
+	if req := srv.WaitRequest(10 * time.Millisecond); req != nil {
+		t.Fatalf("first WaitRequest = %v, want nil on timeout", req)
+	}
+
+	done := make(chan *http.Request, 1)
+	go func() { done <- srv.WaitRequest(time.Second) }()
+
+	if _, err := http.Get(srv.URL() + "/ping"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := <-done
+	if req == nil || req.URL.Path != "/ping" {
+		t.Fatalf("second WaitRequest returned %v, want a /ping request", req)
+	}
+}
+
+func TestFailNextSimulatesA5xxBurst(t *testing.T) {
+	srv := NewServer(t)
+	srv.FailNext(2)
+
+	for i := 0; i < 2; i++ {
+		resp, err := http.Get(srv.URL() + "/flaky")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("request %d status = %d, want 500", i, resp.StatusCode)
+		}
+	}
+
+	resp, err := http.Get(srv.URL() + "/flaky")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("request after burst status = %d, want 200", resp.StatusCode)
+	}
+}
@@ -0,0 +1,33 @@
+package httptestutil
+
+import (
+	"testing"
+)
+
+// SuiteServer is a mixin to embed alongside suite.Suite to give every test
+// a fresh Server via SetupTest/TearDownTest, matching the TEST SUITE SETUP
+// convention in templates/unit/go-unit-test.template_test.go:
+//
+//	type FooTestSuite struct {
+//		suite.Suite
+//		httptestutil.SuiteServer
+//	}
+//
+//	func (s *FooTestSuite) SetupTest() { s.SuiteServer.SetupTest(s.T()) }
+//	func (s *FooTestSuite) TearDownTest() { s.SuiteServer.TearDownTest() }
+type SuiteServer struct {
+	Server *Server
+}
+
+// SetupTest starts a fresh Server for the upcoming test.
+func (s *SuiteServer) SetupTest(t *testing.T) {
+	s.Server = NewServer(t)
+}
+
+// TearDownTest drains any pending WaitRequest callers. The server itself
+// is closed via t.Cleanup registered in NewServer.
+func (s *SuiteServer) TearDownTest() {
+	if s.Server != nil {
+		s.Server.Flush()
+	}
+}
@@ -0,0 +1,401 @@
+// Package gotestgen generates table-driven test scaffolds for a Go package
+// by inspecting its exported API surface. It is the engine behind the
+// `gotestgen` CLI (cmd/gotestgen) and follows the same suite/table/mock/fuzz
+// conventions used by templates/unit/go-unit-test.template_test.go, except
+// the placeholders are filled in from real declarations instead of by hand.
+//
+// Scope: Inspect is a go/ast walk of a single package directory with no
+// import resolution, so it can fully introspect interfaces declared in that
+// same package (real per-method mock stubs, see Param.IfaceMethods) but not
+// ones declared in an imported package (stdlib or otherwise) — that needs a
+// type-checked, whole-module view via go/types (and an importer capable of
+// resolving the target's dependencies), which this package deliberately
+// doesn't take on. Generated table stubs are likewise one happy-path row
+// per function, plus one error-path row when the function returns an
+// error, not a row per branch reachable via
+// control-flow/SSA analysis: accurately enumerating branches (and which
+// inputs reach them) is a program-analysis problem in its own right, not a
+// mechanical AST walk, so gotestgen leaves that stub for a human to fill in
+// rather than guessing. Both are intentionally out of scope for the same
+// reason: getting them wrong silently (a mock that doesn't implement the
+// real interface, a "branch" row that doesn't correspond to a reachable
+// path) is worse than a TODO a human has to finish.
+package gotestgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Section identifies one of the stub kinds gotestgen can emit. They map
+// directly onto the --only=table,mock,fuzz filter values.
+type Section string
+
+const (
+	SectionTable Section = "table"
+	SectionMock  Section = "mock"
+	SectionFuzz  Section = "fuzz"
+)
+
+// Options controls how Render renders test files.
+type Options struct {
+	// Sections restricts which stub kinds are emitted. A nil/empty set
+	// means "all sections".
+	Sections map[Section]bool
+	// Update preserves user edits inside `// gotestgen:begin NAME` /
+	// `// gotestgen:end NAME` protected regions found in an existing
+	// destination file, only adding stubs for newly discovered symbols.
+	Update bool
+}
+
+func (o Options) wants(s Section) bool {
+	if len(o.Sections) == 0 {
+		return true
+	}
+	return o.Sections[s]
+}
+
+// Func describes an exported top-level function or method discovered in a
+// source file.
+type Func struct {
+	Name       string
+	Receiver   string // empty for plain functions
+	Params     []Param
+	Results    []string
+	ReturnsErr bool
+}
+
+// Param describes one parameter of a Func, including whether its type is an
+// interface (the signal gotestgen uses to decide a mock is needed).
+type Param struct {
+	Name        string
+	Type        string
+	IsInterface bool
+	// IfaceMethods is the method set of Type, when Type names an interface
+	// declared somewhere in the inspected package. It is nil when Type is
+	// an interface gotestgen can't introspect — e.g. one declared in an
+	// imported package — since that needs type-checked import resolution
+	// (go/types) rather than the single-package go/ast walk Inspect does;
+	// see the package doc comment.
+	IfaceMethods []Func
+}
+
+// Type describes an exported type declaration.
+type Type struct {
+	Name        string
+	IsInterface bool
+	Methods     []Func
+}
+
+// SourceFile is the result of inspecting one .go file in the target package.
+type SourceFile struct {
+	Path      string
+	Package   string
+	Funcs     []Func
+	Types     []Type
+	Sentinels []string // exported vars matching ^Err[A-Z]
+	// ImportPath is the inspected package's own module-relative import path
+	// (e.g. "example.com/mod/internal/foo"), resolved from the nearest
+	// enclosing go.mod. Render needs this to import the package under test
+	// into the generated external test package; it's empty when no go.mod
+	// is found above dir, in which case Render falls back to an internal
+	// test package instead (see Render's doc comment).
+	ImportPath string
+}
+
+var sentinelRe = regexp.MustCompile(`^Err[A-Z]`)
+
+// Inspect parses dir (a single package directory, non-recursive) and returns
+// one SourceFile per non-test .go source file, in deterministic order.
+func Inspect(dir string) ([]SourceFile, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("gotestgen: parse %s: %w", dir, err)
+	}
+
+	importPath, err := resolveImportPath(dir)
+	if err != nil {
+		return nil, fmt.Errorf("gotestgen: resolve import path for %s: %w", dir, err)
+	}
+
+	var out []SourceFile
+	for _, pkg := range pkgs {
+		names := make([]string, 0, len(pkg.Files))
+		for name := range pkg.Files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			file := pkg.Files[name]
+			sf := SourceFile{Path: name, Package: pkg.Name, ImportPath: importPath}
+
+			methodsByRecv := map[string][]Func{}
+
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					if !d.Name.IsExported() {
+						continue
+					}
+					fn := Func{Name: d.Name.Name, Params: paramsOf(d.Type)}
+					fn.ReturnsErr = returnsErr(d.Type)
+					if d.Recv != nil && len(d.Recv.List) > 0 {
+						recv := recvTypeName(d.Recv.List[0].Type)
+						fn.Receiver = recv
+						methodsByRecv[recv] = append(methodsByRecv[recv], fn)
+						continue
+					}
+					sf.Funcs = append(sf.Funcs, fn)
+
+				case *ast.GenDecl:
+					switch d.Tok {
+					case token.TYPE:
+						for _, spec := range d.Specs {
+							ts, ok := spec.(*ast.TypeSpec)
+							if !ok || !ts.Name.IsExported() {
+								continue
+							}
+							it, isIface := ts.Type.(*ast.InterfaceType)
+							t := Type{Name: ts.Name.Name, IsInterface: isIface}
+							if isIface {
+								t.Methods = ifaceMethodsOf(it)
+							}
+							sf.Types = append(sf.Types, t)
+						}
+					case token.VAR:
+						for _, spec := range d.Specs {
+							vs, ok := spec.(*ast.ValueSpec)
+							if !ok {
+								continue
+							}
+							for _, n := range vs.Names {
+								if n.IsExported() && sentinelRe.MatchString(n.Name) {
+									sf.Sentinels = append(sf.Sentinels, n.Name)
+								}
+							}
+						}
+					}
+				}
+			}
+
+			for i, t := range sf.Types {
+				if !t.IsInterface {
+					sf.Types[i].Methods = methodsByRecv[t.Name]
+				}
+			}
+
+			out = append(out, sf)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	resolveIfaceParams(out)
+	return out, nil
+}
+
+var moduleLineRe = regexp.MustCompile(`^module\s+(\S+)`)
+
+// resolveImportPath returns dir's Go import path, derived from the module
+// path declared in the nearest go.mod above dir plus dir's relative path
+// under that module root. It returns "" (not an error) when no go.mod is
+// found, so callers outside a module still get a best-effort result.
+func resolveImportPath(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	modDir := absDir
+	for {
+		data, err := os.ReadFile(filepath.Join(modDir, "go.mod"))
+		if err == nil {
+			m := moduleLineRe.FindSubmatch(data)
+			if m == nil {
+				return "", fmt.Errorf("%s: no module directive", filepath.Join(modDir, "go.mod"))
+			}
+			modulePath := string(m[1])
+
+			rel, err := filepath.Rel(modDir, absDir)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return modulePath, nil
+			}
+			return modulePath + "/" + filepath.ToSlash(rel), nil
+		}
+
+		parent := filepath.Dir(modDir)
+		if parent == modDir {
+			return "", nil // reached filesystem root without finding a go.mod
+		}
+		modDir = parent
+	}
+}
+
+// resolveIfaceParams marks each Func parameter whose type names an
+// interface declared anywhere in the package (not just an inline
+// `interface{}` literal, which paramsOf already catches) and, for those,
+// records the interface's own method set so mock generation can emit real
+// method stubs instead of a placeholder. See the package doc comment for
+// why this stops at the package boundary.
+func resolveIfaceParams(files []SourceFile) {
+	ifaces := map[string][]Func{}
+	for _, sf := range files {
+		for _, t := range sf.Types {
+			if t.IsInterface {
+				ifaces[t.Name] = t.Methods
+			}
+		}
+	}
+	if len(ifaces) == 0 {
+		return
+	}
+
+	mark := func(p *Param) {
+		name := strings.TrimPrefix(p.Type, "*")
+		if methods, ok := ifaces[name]; ok {
+			p.IsInterface = true
+			p.IfaceMethods = methods
+		}
+	}
+	for i := range files {
+		for j := range files[i].Funcs {
+			params := files[i].Funcs[j].Params
+			for k := range params {
+				mark(&params[k])
+			}
+		}
+		for j := range files[i].Types {
+			methods := files[i].Types[j].Methods
+			for k := range methods {
+				params := methods[k].Params
+				for l := range params {
+					mark(&params[l])
+				}
+			}
+		}
+	}
+}
+
+// ifaceMethodsOf extracts one Func per explicitly-declared method on an
+// interface type. Embedded interfaces (fields with no Names) are skipped
+// rather than expanded, since that set could itself reach into an imported
+// package — the same boundary Inspect draws everywhere else.
+func ifaceMethodsOf(it *ast.InterfaceType) []Func {
+	if it.Methods == nil {
+		return nil
+	}
+	var methods []Func
+	for _, field := range it.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			continue
+		}
+		for _, name := range field.Names {
+			methods = append(methods, Func{
+				Name:       name.Name,
+				Params:     paramsOf(ft),
+				Results:    resultsOf(ft),
+				ReturnsErr: returnsErr(ft),
+			})
+		}
+	}
+	return methods
+}
+
+func resultsOf(ft *ast.FuncType) []string {
+	if ft.Results == nil {
+		return nil
+	}
+	var results []string
+	for _, field := range ft.Results.List {
+		typ := exprString(field.Type)
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			results = append(results, typ)
+		}
+	}
+	return results
+}
+
+func paramsOf(ft *ast.FuncType) []Param {
+	if ft.Params == nil {
+		return nil
+	}
+	var params []Param
+	for _, field := range ft.Params.List {
+		typ := exprString(field.Type)
+		_, isIface := field.Type.(*ast.InterfaceType)
+		names := field.Names
+		if len(names) == 0 {
+			params = append(params, Param{Type: typ, IsInterface: isIface})
+			continue
+		}
+		for _, n := range names {
+			params = append(params, Param{Name: n.Name, Type: typ, IsInterface: isIface})
+		}
+	}
+	return params
+}
+
+func returnsErr(ft *ast.FuncType) bool {
+	if ft.Results == nil {
+		return false
+	}
+	for _, field := range ft.Results.List {
+		if exprString(field.Type) == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+func recvTypeName(e ast.Expr) string {
+	if star, ok := e.(*ast.StarExpr); ok {
+		e = star.X
+	}
+	if id, ok := e.(*ast.Ident); ok {
+		return id.Name
+	}
+	return exprString(e)
+}
+
+func exprString(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + exprString(t.X)
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + exprString(t.Elt)
+	case *ast.InterfaceType:
+		return "interface{}"
+	default:
+		return fmt.Sprintf("%T", e)
+	}
+}
+
+// DestPath returns the generated test file path for a given source file,
+// e.g. "foo.go" -> "foo_test.go".
+func DestPath(srcPath string) string {
+	base := filepath.Base(srcPath)
+	return strings.TrimSuffix(base, ".go") + "_test.go"
+}
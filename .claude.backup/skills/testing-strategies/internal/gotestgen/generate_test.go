@@ -0,0 +1,173 @@
+package gotestgen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInspectFindsExportedSymbols(t *testing.T) {
+	files, err := Inspect("testdata/sample")
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("want 1 source file, got %d", len(files))
+	}
+
+	sf := files[0]
+	if sf.Package != "sample" {
+		t.Errorf("package = %q, want %q", sf.Package, "sample")
+	}
+
+	var gotFuncs []string
+	for _, fn := range sf.Funcs {
+		gotFuncs = append(gotFuncs, fn.Name)
+	}
+	wantFuncs := []string{"Greet", "Process", "Save"}
+	if strings.Join(gotFuncs, ",") != strings.Join(wantFuncs, ",") {
+		t.Errorf("funcs = %v, want %v", gotFuncs, wantFuncs)
+	}
+
+	if len(sf.Sentinels) != 1 || sf.Sentinels[0] != "ErrEmpty" {
+		t.Errorf("sentinels = %v, want [ErrEmpty]", sf.Sentinels)
+	}
+}
+
+func TestRenderProducesTableStubPerFunc(t *testing.T) {
+	files, err := Inspect("testdata/sample")
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+
+	out := Render(files[0], Options{}, nil)
+
+	for _, want := range []string{"func TestGreet", "func TestProcess", "func TestErrorIsErrEmpty"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered output missing %q", want)
+		}
+	}
+}
+
+func TestRenderPreservesProtectedRegions(t *testing.T) {
+	files, err := Inspect("testdata/sample")
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+
+	existing := map[string]string{
+		"TestGreet": "\tt.Log(\"hand-written assertion\")\n",
+	}
+
+	out := Render(files[0], Options{}, existing)
+	if !strings.Contains(out, "hand-written assertion") {
+		t.Errorf("rendered output did not preserve protected region content:\n%s", out)
+	}
+}
+
+func TestRenderGeneratesRealMockForLocalInterface(t *testing.T) {
+	files, err := Inspect("testdata/sample")
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+
+	out := Render(files[0], Options{}, nil)
+
+	for _, want := range []string{
+		"type MockStore struct {",
+		"func (m *MockStore) Get(key string) (string, error) {",
+		"func (m *MockStore) Put(key string, value string) error {",
+		"args.Error(0)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "TODO: add one method stub") {
+		t.Errorf("Store is declared locally; gotestgen should not fall back to the placeholder mock:\n%s", out)
+	}
+}
+
+// TestRenderOutputCompiles catches what a strings.Contains check can't: it
+// actually builds the rendered file. It copies testdata/sample into a
+// throwaway module (so Inspect resolves a real ImportPath for it, same as
+// any real package under a go.mod), writes Render's output alongside it,
+// then resolves github.com/stretchr/testify from the local module cache
+// and runs `go vet` over the result.
+func TestRenderOutputCompiles(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not on PATH")
+	}
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module scratch\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+
+	srcDir := filepath.Join(root, "sample")
+	if err := os.Mkdir(srcDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	src, err := os.ReadFile("testdata/sample/sample.go")
+	if err != nil {
+		t.Fatalf("read testdata/sample/sample.go: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "sample.go"), src, 0o644); err != nil {
+		t.Fatalf("write sample.go: %v", err)
+	}
+
+	files, err := Inspect(srcDir)
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if files[0].ImportPath != "scratch/sample" {
+		t.Fatalf("ImportPath = %q, want %q", files[0].ImportPath, "scratch/sample")
+	}
+	out := Render(files[0], Options{}, nil)
+	if err := os.WriteFile(filepath.Join(srcDir, "sample_test.go"), []byte(out), 0o644); err != nil {
+		t.Fatalf("write sample_test.go: %v", err)
+	}
+
+	modCacheOut, err := exec.Command(goBin, "env", "GOMODCACHE").Output()
+	if err != nil {
+		t.Fatalf("go env GOMODCACHE: %v", err)
+	}
+	modCache := strings.TrimSpace(string(modCacheOut))
+	env := append(os.Environ(),
+		"GOFLAGS=-mod=mod",
+		"GOPROXY=file://"+filepath.ToSlash(modCache)+"/cache/download",
+		"GOSUMDB=off",
+	)
+
+	tidy := exec.Command(goBin, "mod", "tidy")
+	tidy.Dir = root
+	tidy.Env = env
+	if tidyOut, err := tidy.CombinedOutput(); err != nil {
+		t.Skipf("github.com/stretchr/testify not available in local module cache, skipping compile check: %v\n%s", err, tidyOut)
+	}
+
+	vet := exec.Command(goBin, "vet", "./...")
+	vet.Dir = root
+	vet.Env = env
+	if vetOut, err := vet.CombinedOutput(); err != nil {
+		t.Fatalf("rendered test file does not compile:\n%s", vetOut)
+	}
+}
+
+func TestOnlyFilterRestrictsSections(t *testing.T) {
+	files, err := Inspect("testdata/sample")
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+
+	out := Render(files[0], Options{Sections: map[Section]bool{SectionFuzz: true}}, nil)
+	if strings.Contains(out, "TABLE-DRIVEN") {
+		t.Errorf("--only=fuzz should not emit the table section")
+	}
+	if !strings.Contains(out, "FUZZING") {
+		t.Errorf("--only=fuzz should emit the fuzz section")
+	}
+}
@@ -0,0 +1,40 @@
+package gotestgen
+
+import (
+	"bufio"
+	"strings"
+)
+
+// ParseProtectedRegions scans an existing generated test file and returns
+// the body text captured between each `// gotestgen:begin NAME` and the
+// matching `// gotestgen:end NAME`, keyed by NAME. Render uses this to
+// preserve user edits across --update runs instead of regenerating stubs
+// that have already been filled in.
+func ParseProtectedRegions(src string) map[string]string {
+	regions := map[string]string{}
+
+	var name string
+	var body strings.Builder
+	inRegion := false
+
+	scanner := bufio.NewScanner(strings.NewReader(src))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(strings.TrimSpace(line), "// gotestgen:begin "):
+			name = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "// gotestgen:begin "))
+			inRegion = true
+			body.Reset()
+		case strings.HasPrefix(strings.TrimSpace(line), "// gotestgen:end "):
+			if inRegion {
+				regions[name] = body.String()
+			}
+			inRegion = false
+		case inRegion:
+			body.WriteString(line)
+			body.WriteString("\n")
+		}
+	}
+
+	return regions
+}
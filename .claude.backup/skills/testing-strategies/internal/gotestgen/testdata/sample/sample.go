@@ -0,0 +1,34 @@
+// Package sample is a small fixture package used by gotestgen's own tests.
+package sample
+
+import "errors"
+
+// ErrEmpty is returned by Process when given an empty string.
+var ErrEmpty = errors.New("sample: empty input")
+
+// Greet returns a greeting for name.
+func Greet(name string) string {
+	return "hello, " + name
+}
+
+// Process validates and normalizes input, returning ErrEmpty if input is "".
+func Process(input string) (string, error) {
+	if input == "" {
+		return "", ErrEmpty
+	}
+	return input, nil
+}
+
+func unexported() {}
+
+// Store is a key/value backend; it's declared in this package so gotestgen
+// can generate a real mock for it from its own method set.
+type Store interface {
+	Get(key string) (string, error)
+	Put(key, value string) error
+}
+
+// Save writes input into store under key.
+func Save(store Store, key, input string) error {
+	return store.Put(key, input)
+}
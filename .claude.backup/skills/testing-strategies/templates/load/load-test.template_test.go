@@ -0,0 +1,49 @@
+//go:build ignore
+
+// This file is a copy-paste template, not a compilable package: the
+// {placeholder} tokens below are meant to be replaced with real names
+// before it becomes part of any build. The ignore tag keeps it out of
+// `go build ./...` / `go vet ./...` in the meantime.
+
+package {packagename}_test
+
+/*
+Go Load Test Template
+
+Purpose: Drive {packagename} under sustained or ramping concurrent load and
+         fail when error rate or tail latency crosses a threshold.
+Framework: harness (see ../../harness) + testing
+
+Usage:
+    1. Copy this template and testdata/scenario.json alongside it
+    2. Replace {packagename}, {FuncName} with actual names
+    3. Fill in the scenario's concurrency/duration/thresholds
+    4. Run: go test -run TestLoad -v ./...
+    or standalone: loadtest --config testdata/scenario.json
+*/
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"{module}/harness"
+	"{module}/{packagename}"
+)
+
+// TestLoad drives {packagename}.{FuncName} via the "func-call" test type so
+// the same scenario file can also be run standalone with the loadtest CLI.
+func TestLoad(t *testing.T) {
+	harness.RegisterFunc("{FuncName}", func(ctx context.Context) error {
+		return {packagename}.{FuncName}(ctx)
+	})
+
+	cfg, err := harness.LoadConfig("testdata/scenario.json")
+	require.NoError(t, err)
+
+	result, err := harness.NewRunner(cfg, os.Stdout).Run(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, result.ThresholdsFailed, "load test exceeded configured thresholds")
+}
@@ -1,3 +1,10 @@
+//go:build ignore
+
+// This file is a copy-paste template, not a compilable package: the
+// {placeholder} tokens below are meant to be replaced with real names
+// before it becomes part of any build. The ignore tag keeps it out of
+// `go build ./...` / `go vet ./...` in the meantime.
+
 package {packagename}_test
 
 /*
@@ -23,6 +30,7 @@ Installation:
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -32,6 +40,10 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"{module}/{packagename}"
+	"{module}/benchreport"
+	"{module}/fuzzutil"
+	"{module}/httptestutil"
+	"{module}/testreport"
 )
 
 // ============================================================================
@@ -40,6 +52,7 @@ import (
 
 type {TypeName}TestSuite struct {
 	suite.Suite
+	httptestutil.SuiteServer
 	instance    *{packagename}.{TypeName}
 	mockDep     *MockDependency
 	testData    map[string]interface{}
@@ -57,6 +70,8 @@ func (s *{TypeName}TestSuite) TearDownSuite() {
 
 // SetupTest runs before each test
 func (s *{TypeName}TestSuite) SetupTest() {
+	testreport.Mark(s.T())
+	s.SuiteServer.SetupTest(s.T())
 	s.mockDep = new(MockDependency)
 	s.instance = {packagename}.New{TypeName}(s.mockDep)
 	s.testData = map[string]interface{}{
@@ -67,6 +82,7 @@ func (s *{TypeName}TestSuite) SetupTest() {
 
 // TearDownTest runs after each test
 func (s *{TypeName}TestSuite) TearDownTest() {
+	s.SuiteServer.TearDownTest()
 	s.mockDep = nil
 	s.instance = nil
 }
@@ -107,6 +123,8 @@ func (s *{TypeName}TestSuite) TestReturnsCorrectType() {
 // ============================================================================
 
 func TestFunctionName(t *testing.T) {
+	testreport.Mark(t)
+
 	tests := []struct {
 		name     string
 		input    string
@@ -279,6 +297,48 @@ func (s *{TypeName}TestSuite) TestHandlesDependencyFailure() {
 	assert.Contains(s.T(), err.Error(), "dependency failed")
 }
 
+// ============================================================================
+// HTTP CLIENT TESTS (httptestutil)
+// ============================================================================
+
+// For a Method that talks to a real HTTP dependency rather than an
+// in-process interface, use httptestutil.Server instead of hand-rolling
+// httptest.NewServer boilerplate per test. Embed httptestutil.SuiteServer
+// in the suite above and wire it through SetupTest/TearDownTest to get a
+// fresh server per test automatically.
+func (s *{TypeName}TestSuite) TestCallsUpstreamAPICorrectly() {
+	// Arrange
+	s.Server.Respond("/widgets", httptestutil.Response{
+		Status: http.StatusOK,
+		Body:   []byte(`{"id":1,"name":"test"}`),
+	})
+
+	// Act
+	result, err := s.instance.FetchWidget(s.Server.URL(), 1)
+
+	// Assert
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "test", result.Name)
+
+	// The call above has already returned, so inspect the recorded requests;
+	// WaitRequest only sees requests that arrive after it is called.
+	reqs := s.Server.Requests()
+	require.Len(s.T(), reqs, 1, "expected an outbound request")
+	assert.Equal(s.T(), "/widgets", reqs[0].URL.Path)
+}
+
+func (s *{TypeName}TestSuite) TestRetriesOn5xxBurst() {
+	// Arrange: simulate two transient failures before the dependency recovers
+	s.Server.FailNext(2)
+
+	// Act
+	_, err := s.instance.FetchWidget(s.Server.URL(), 1)
+
+	// Assert
+	require.NoError(s.T(), err, "client should retry through a transient 5xx burst")
+	assert.Len(s.T(), s.Server.Requests(), 3)
+}
+
 // ============================================================================
 // CONTEXT AND TIMEOUT TESTS
 // ============================================================================
@@ -349,6 +409,19 @@ func BenchmarkWithSetup(b *testing.B) {
 //   go test -bench=. -benchmem
 //   go test -bench=BenchmarkFunctionName -benchtime=5s
 //   go test -bench=. -cpuprofile=cpu.prof
+//
+// A/B comparison workflow with benchreport:
+//   1. On the base commit:   go test -bench=. -benchreport=old.txt
+//   2. On the change:        go test -bench=. -benchreport=new.txt
+//   3. Compare:              benchreport.Compare("old.txt", "new.txt")
+// -benchreport is wired in TestMain below; it writes the []benchreport.Result
+// passed to benchreport.WriteFile in the golang.org/design/14313 format
+// benchstat also reads. benchreport does not hook into testing.B, so
+// ns/op, allocs/op, bytes/op and b.ReportMetric values are only in the file
+// if the package fills in those Results itself; as written, TestMain passes
+// nil and the file holds the header alone.
+// Compare flags any benchmark whose delta survives a Welch's t-test at
+// p < 0.05, so noisy reruns don't get reported as regressions.
 
 // ============================================================================
 // EXAMPLE TESTS (shown in godoc)
@@ -412,34 +485,70 @@ var update = flag.Bool("update", false, "update golden files")
 // ============================================================================
 
 func FuzzFunctionName(f *testing.F) {
-	// Seed corpus
+	// Seed corpus: f.Add calls below, plus anything saved under
+	// testdata/fuzz/FuzzFunctionName/ in the `go test fuzz v1` format that
+	// `go test -fuzz` itself writes for discovered failures. Load both with
+	// fuzzutil.LoadCorpus so hand-written and discovered seeds run together.
+	seedCount, err := fuzzutil.LoadCorpus(f, "testdata/fuzz/FuzzFunctionName")
+	if err != nil {
+		f.Fatal(err)
+	}
+	testreport.SetFuzzSeedCount(seedCount)
 	f.Add("test")
 	f.Add("example")
 	f.Add("")
 
 	f.Fuzz(func(t *testing.T, input string) {
-		// This should never panic
-		result := {FunctionName}(input)
+		fuzzutil.NeverPanics(t, func() {
+			result := {FunctionName}(input)
 
-		// Properties that should always hold
-		assert.NotNil(t, result)
-		assert.True(t, len(result) >= 0)
+			// Properties that should always hold
+			assert.NotNil(t, result)
+			assert.True(t, len(result) >= 0)
+		})
 	})
 }
 
 // Run fuzzing:
 //   go test -fuzz=FuzzFunctionName -fuzztime=30s
+//
+// When fuzzing finds a failing input, `go test` writes it under
+// testdata/fuzz/FuzzFunctionName/<hash>; rename it to something descriptive
+// and check it in as a permanent regression seed (see
+// fuzzutil/testdata/fuzz/FuzzExampleRoundTrip/minimized_unicode_nul for a
+// worked example of a minimized, checked-in crash input).
+//
+// In CI, bound total fuzz time per package instead of running the default
+// short smoke pass:
+//   fuzzbudget --fuzz-budget=30s ./path/to/package
+// fuzzbudget exits non-zero when a target produces a new corpus entry or
+// fails to build or run, not merely because its time budget elapsed.
 
 // ============================================================================
 // TEST MAIN (for global setup/teardown)
 // ============================================================================
 
+var benchreportPath = flag.String("benchreport", "", "write captured benchmark results to this file in golang.org/design/14313 format")
+
 func TestMain(m *testing.M) {
 	// Global setup
 	setup()
 
-	// Run tests
-	code := m.Run()
+	// Run tests. testreport.RunM wraps m.Run() to detect a mistyped -run
+	// regex or an empty suite reporting a deceptive PASS: pass
+	// -require-tests in CI to turn "ran=0" into a failure, and grep the
+	// printed summary line ("ok {packagename} 0.01s [ran=12 skipped=2
+	// fuzz-seeds=44]") for suspicious [ran=0] results across a monorepo.
+	code := testreport.RunM(m, "{packagename}")
+
+	if *benchreportPath != "" {
+		// No results are passed here (nil), so only the header is written;
+		// in a real package, pass the Result values collected from each
+		// Benchmark* run.
+		if err := benchreport.WriteFile(*benchreportPath, benchreport.CurrentHeader("{packagename}", "", ""), nil); err != nil {
+			fmt.Fprintln(os.Stderr, "benchreport:", err)
+		}
+	}
 
 	// Global teardown
 	teardown()
@@ -0,0 +1,86 @@
+// Package testreport wraps testing.M.Run() with instrumentation that
+// detects "no tests actually ran" — a mistyped -run regex or an empty
+// suite that would otherwise silently report PASS — and prints a
+// per-package summary line CI can grep across a monorepo.
+package testreport
+
+import (
+	"flag"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+var requireTests = flag.Bool("require-tests", false, "fail instead of passing when zero tests (or zero matching -run) actually ran")
+
+// registry is populated by Mark, called from each test (directly, or once
+// per suite from SetupTest) to record that it actually executed.
+var registry = struct {
+	mu      sync.Mutex
+	ran     int
+	skipped int
+}{}
+
+// fuzzSeedCount is set via SetFuzzSeedCount, since seed counts come from
+// fuzzutil.LoadCorpus rather than from an executed test.
+var fuzzSeedCount int
+
+// Mark records that t executed, for the summary line and -require-tests
+// check. Call it at the top of each top-level test and from SetupTest for
+// suite-based tests (see templates/unit/go-unit-test.template_test.go).
+func Mark(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+		if t.Skipped() {
+			registry.skipped++
+		} else {
+			registry.ran++
+		}
+	})
+}
+
+// SetFuzzSeedCount records how many fuzz corpus seeds were loaded this run
+// (typically the sum of fuzzutil.LoadCorpus's return across FuzzXxx
+// functions), for inclusion in the summary line.
+func SetFuzzSeedCount(n int) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	fuzzSeedCount = n
+}
+
+// RunM runs m, then checks the registry and prints a summary line of the
+// form:
+//
+//	ok pkg 0.01s [ran=12 skipped=2 fuzz-seeds=44]
+//
+// If zero tests ran — either because the suite is empty or because -run
+// matched nothing — it prints "no tests to run"; if -require-tests was
+// also passed, it forces a non-zero exit code instead of the deceptive
+// PASS testing.M would otherwise report.
+func RunM(m *testing.M, pkg string) int {
+	start := time.Now()
+	code := m.Run()
+	elapsed := time.Since(start)
+
+	registry.mu.Lock()
+	ran, skipped := registry.ran, registry.skipped
+	registry.mu.Unlock()
+
+	if ran == 0 {
+		fmt.Println("no tests to run")
+		if *requireTests {
+			code = 1
+		}
+	}
+
+	status := "ok"
+	if code != 0 {
+		status = "FAIL"
+	}
+	fmt.Printf("%s %s %.2fs [ran=%d skipped=%d fuzz-seeds=%d]\n", status, pkg, elapsed.Seconds(), ran, skipped, fuzzSeedCount)
+
+	return code
+}
@@ -0,0 +1,45 @@
+package testreport
+
+import (
+	"testing"
+)
+
+func TestMarkRecordsRanOnSuccess(t *testing.T) {
+	before := registry.ran
+
+	t.Run("inner", func(t *testing.T) {
+		Mark(t)
+	})
+
+	registry.mu.Lock()
+	after := registry.ran
+	registry.mu.Unlock()
+
+	if after != before+1 {
+		t.Fatalf("registry.ran = %d, want %d", after, before+1)
+	}
+}
+
+func TestMarkRecordsSkippedOnSkip(t *testing.T) {
+	before := registry.skipped
+
+	t.Run("inner", func(t *testing.T) {
+		Mark(t)
+		t.Skip("intentionally skipped")
+	})
+
+	registry.mu.Lock()
+	after := registry.skipped
+	registry.mu.Unlock()
+
+	if after != before+1 {
+		t.Fatalf("registry.skipped = %d, want %d", after, before+1)
+	}
+}
+
+func TestSetFuzzSeedCountIsReflectedInSummary(t *testing.T) {
+	SetFuzzSeedCount(7)
+	if fuzzSeedCount != 7 {
+		t.Fatalf("fuzzSeedCount = %d, want 7", fuzzSeedCount)
+	}
+}